@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// newCreateCmd builds the `openshift-install create` command, whose
+// subcommands create the pieces of an OpenShift cluster.
+func newCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create part of an OpenShift cluster",
+	}
+	cmd.AddCommand(newCreateClusterCmd())
+	return cmd
+}
+
+// newCreateClusterCmd builds the `openshift-install create cluster`
+// command. With --dry-run, it previews the Terraform plan for the
+// stage instead of applying it.
+func newCreateClusterCmd() *cobra.Command {
+	var dir string
+	var platform string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Create an OpenShift cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if dryRun {
+				return runDryRun(dir, platform)
+			}
+
+			_, err := terraform.Apply(dir, platform)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", ".", "assets directory to create the cluster from")
+	cmd.Flags().StringVar(&platform, "platform", "", "target platform")
+	addDryRunFlag(cmd, &dryRun)
+
+	return cmd
+}
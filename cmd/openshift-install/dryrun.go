@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// addDryRunFlag adds --dry-run to `create cluster`, letting operators
+// preview the infrastructure changes for a stage (bootstrap, masters,
+// workers) before touching a cloud account.
+func addDryRunFlag(cmd *cobra.Command, dryRun *bool) {
+	cmd.Flags().BoolVar(dryRun, "dry-run", false, "Report the Terraform plan for this stage instead of applying it")
+}
+
+// runDryRun runs 'terraform plan' for dir/platform and prints a
+// human-readable summary of the adds/changes/destroys it found.
+func runDryRun(dir, platform string, extraArgs ...string) error {
+	_, changes, err := terraform.Plan(dir, platform, extraArgs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate Terraform plan")
+	}
+
+	fmt.Printf("Plan: %d to add, %d to change, %d to destroy\n", len(changes.Adds), len(changes.Changes), len(changes.Destroys))
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/installer/pkg/terraform"
+	"github.com/openshift/installer/pkg/terraform/inventory"
+)
+
+// newInventoryCmd builds the `openshift-install inventory` command,
+// which parses the tfstate an Apply left behind and emits it as an
+// Ansible dynamic inventory so day-2 configuration management can pick
+// up the hosts it created.
+func newInventoryCmd() *cobra.Command {
+	var dir string
+	var list bool
+	var host string
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Emit an Ansible dynamic inventory from the cluster's Terraform state",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(filepath.Join(dir, terraform.StateFileName))
+			if err != nil {
+				return errors.Wrap(err, "failed to read Terraform state")
+			}
+
+			inv, err := inventory.Parse(data)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse Terraform state")
+			}
+
+			var doc interface{}
+			switch {
+			case host != "":
+				doc = inv.HostVars(host)
+			case list:
+				doc = inv.List()
+			default:
+				return errors.New("one of --list or --host must be given")
+			}
+
+			out, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal inventory")
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", ".", "assets directory containing the Terraform state")
+	cmd.Flags().BoolVar(&list, "list", false, "List all hosts (ansible-inventory --list)")
+	cmd.Flags().StringVar(&host, "host", "", "Show vars for a single host (ansible-inventory --host)")
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+// Command openshift-install creates OpenShift clusters.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if maybeServeEmbeddedPlugin() {
+		return
+	}
+
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "openshift-install",
+		Short: "Creates OpenShift clusters",
+	}
+	root.AddCommand(newCreateCmd())
+	root.AddCommand(newInventoryCmd())
+	return root
+}
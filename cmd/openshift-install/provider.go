@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/plugin"
+)
+
+// maybeServeEmbeddedPlugin checks whether this binary was invoked
+// under one of the names setupEmbeddedPlugins symlinks it to and, if
+// so, serves that Terraform plugin instead of running the CLI. It
+// reports whether it served a plugin, so main() knows not to fall
+// through to the CLI.
+func maybeServeEmbeddedPlugin() bool {
+	switch strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") {
+	case "terraform-provider-noop":
+		plugin.Serve(&plugin.ServeOpts{ProviderFunc: noopProvider})
+		return true
+	default:
+		return false
+	}
+}
+
+// noopProvider is a canned Terraform provider, embedded for the e2e
+// test suite so it can exercise Apply/Destroy without cloud
+// credentials.
+func noopProvider() *schema.Provider {
+	return &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"noop_instance": {
+				Create: noopInstanceCreate,
+				Read:   noopInstanceRead,
+				Delete: noopInstanceDelete,
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func noopInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("name").(string))
+	return nil
+}
+
+func noopInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func noopInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,69 @@
+// Package data embeds the Terraform configuration that ships with
+// the installer: the base CLI config, and the per-platform modules
+// unpacked into each cluster's asset directory (including small
+// fixture platforms used by the e2e test suite).
+package data
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+//go:embed data
+var embedded embed.FS
+
+// Unpack writes the embedded asset named src, relative to this
+// package's data directory, to dst, relative to the current working
+// directory. If src names a directory, its contents are written
+// recursively under dst; otherwise src's single file is written to
+// dst directly.
+func Unpack(dst, src string) error {
+	root := path.Join("data", filepath.ToSlash(src))
+
+	info, err := fs.Stat(embedded, root)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find embedded asset %q", src)
+	}
+
+	if !info.IsDir() {
+		contents, err := embedded.ReadFile(root)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read embedded asset %q", src)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0777); err != nil {
+			return err
+		}
+		return os.WriteFile(dst, contents, 0666)
+	}
+
+	return fs.WalkDir(embedded, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		target := dst
+		if rel != "." {
+			target = filepath.Join(dst, rel)
+		}
+
+		if d.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+
+		contents, err := embedded.ReadFile(p)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read embedded asset %q", p)
+		}
+		return os.WriteFile(target, contents, 0666)
+	})
+}
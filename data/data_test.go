@@ -0,0 +1,34 @@
+package data
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, "config.tf")
+	require.NoError(t, Unpack(dst, "config.tf"))
+
+	contents, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "required_version")
+}
+
+func TestUnpackDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "data-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, Unpack(dir, "noop"))
+
+	assert.FileExists(t, filepath.Join(dir, "main.tf"))
+}
@@ -0,0 +1,22 @@
+package e2etest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDestroyNoop(t *testing.T) {
+	r := NewRunner(t)
+
+	statePath, err := r.Apply("noop")
+	require.NoError(t, err)
+	assert.FileExists(t, statePath)
+
+	rc, err := r.TerraformRC()
+	require.NoError(t, err)
+	assert.Contains(t, rc, "plugin_cache_dir")
+
+	assert.NoError(t, r.Destroy("noop"))
+}
@@ -0,0 +1,58 @@
+// Package e2etest exercises terraform.Apply/terraform.Destroy through
+// their real code paths against the embedded "noop" fixture platform,
+// which returns canned resources so the suite does not need cloud
+// credentials.
+package e2etest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/installer/pkg/terraform"
+)
+
+// Runner drives a fresh asset directory through
+// terraform.Apply/terraform.Destroy, so tests exercise the same
+// unpack/plugin-setup/init plumbing a real cluster install does.
+type Runner struct {
+	t   *testing.T
+	Dir string
+}
+
+// NewRunner creates a fresh asset directory and returns a Runner for
+// it. The directory is removed when the test completes.
+func NewRunner(t *testing.T) *Runner {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "installer-e2etest-asset")
+	if err != nil {
+		t.Fatalf("failed to create asset directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return &Runner{t: t, Dir: dir}
+}
+
+// Apply runs terraform.Apply against the asset directory.
+func (r *Runner) Apply(platform string, extraArgs ...string) (string, error) {
+	r.t.Helper()
+	return terraform.Apply(r.Dir, platform, extraArgs...)
+}
+
+// Destroy runs terraform.Destroy against the asset directory.
+func (r *Runner) Destroy(platform string, extraArgs ...string) error {
+	r.t.Helper()
+	return terraform.Destroy(r.Dir, platform, extraArgs...)
+}
+
+// TerraformRC returns the contents of the terraform.rc unpacked for
+// this run, for tests asserting on the plugin-cache stanza.
+func (r *Runner) TerraformRC() (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(r.Dir, "terraform.rc"))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
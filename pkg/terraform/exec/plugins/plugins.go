@@ -0,0 +1,17 @@
+// Package plugins enumerates the Terraform provider plugins embedded
+// in the openshift-install binary.
+package plugins
+
+// Plugin identifies an embedded Terraform provider plugin by the name
+// and version Terraform's plugin discovery expects it under.
+type Plugin struct {
+	Name    string
+	Version string
+}
+
+// KnownPlugins maps each embedded plugin's binary name to its
+// identifying metadata. "noop" is a canned provider used by the e2e
+// test suite so it does not need cloud credentials.
+var KnownPlugins = map[string]Plugin{
+	"terraform-provider-noop": {Name: "noop", Version: "1.0.0"},
+}
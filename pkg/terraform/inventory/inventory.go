@@ -0,0 +1,254 @@
+// Package inventory parses a Terraform state file into an Ansible
+// dynamic inventory, so that day-2 configuration management can pick
+// up the hosts a cluster's infrastructure created without hand-rolling
+// a state parser. Both the pre-0.12 flat resource layout and the
+// current values.root_module.resources shape (as produced by
+// `terraform show -json`) are supported.
+package inventory
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Host is a single inventory host derived from a Terraform resource.
+type Host struct {
+	// Name is the resource address, used as the Ansible host name.
+	Name string
+	// Group is the Terraform module the resource came from (e.g.
+	// "master", "worker", "bootstrap"), or "root" for the root module.
+	Group string
+	// Type is the Terraform resource type (e.g. "aws_instance").
+	Type string
+	// Vars are the per-host variables Ansible exposes for this host.
+	Vars map[string]interface{}
+}
+
+// Inventory is the set of hosts extracted from a Terraform state.
+type Inventory struct {
+	Hosts []Host
+}
+
+// Parse detects which of the two supported tfstate shapes data is in
+// and parses it into an Inventory.
+func Parse(data []byte) (*Inventory, error) {
+	var probe struct {
+		Modules []json.RawMessage `json:"modules"`
+		Values  json.RawMessage   `json:"values"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Terraform state")
+	}
+
+	switch {
+	case probe.Values != nil:
+		return parseValues(data)
+	case probe.Modules != nil:
+		return parseFlat(data)
+	default:
+		return nil, errors.New("unrecognized Terraform state format")
+	}
+}
+
+type flatState struct {
+	Modules []struct {
+		Path      []string `json:"path"`
+		Resources map[string]struct {
+			Type    string `json:"type"`
+			Primary struct {
+				ID         string            `json:"id"`
+				Attributes map[string]string `json:"attributes"`
+			} `json:"primary"`
+		} `json:"resources"`
+	} `json:"modules"`
+}
+
+func parseFlat(data []byte) (*Inventory, error) {
+	var state flatState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal flat Terraform state")
+	}
+
+	inv := &Inventory{}
+	for _, mod := range state.Modules {
+		group := "root"
+		if len(mod.Path) > 0 {
+			group = mod.Path[len(mod.Path)-1]
+		}
+		for name, res := range mod.Resources {
+			attrs := make(map[string]interface{}, len(res.Primary.Attributes))
+			for k, v := range res.Primary.Attributes {
+				attrs[k] = v
+			}
+			if !isComputeResource(res.Type, attrs) {
+				continue
+			}
+			inv.Hosts = append(inv.Hosts, Host{
+				Name:  name,
+				Group: group,
+				Type:  res.Type,
+				Vars:  hostVars(res.Primary.ID, attrs),
+			})
+		}
+	}
+	return inv, nil
+}
+
+type jsonResource struct {
+	Address string                 `json:"address"`
+	Type    string                 `json:"type"`
+	Name    string                 `json:"name"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+type jsonModule struct {
+	Address      string         `json:"address"`
+	Resources    []jsonResource `json:"resources"`
+	ChildModules []jsonModule   `json:"child_modules"`
+}
+
+func parseValues(data []byte) (*Inventory, error) {
+	var state struct {
+		Values struct {
+			RootModule jsonModule `json:"root_module"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Terraform state")
+	}
+
+	inv := &Inventory{}
+	walkModule(state.Values.RootModule, inv)
+	return inv, nil
+}
+
+func walkModule(mod jsonModule, inv *Inventory) {
+	group := "root"
+	if mod.Address != "" {
+		parts := strings.Split(mod.Address, ".")
+		group = parts[len(parts)-1]
+	}
+
+	for _, res := range mod.Resources {
+		if !isComputeResource(res.Type, res.Values) {
+			continue
+		}
+		id, _ := res.Values["id"].(string)
+		inv.Hosts = append(inv.Hosts, Host{
+			Name:  res.Address,
+			Group: group,
+			Type:  res.Type,
+			Vars:  hostVars(id, res.Values),
+		})
+	}
+	for _, child := range mod.ChildModules {
+		walkModule(child, inv)
+	}
+}
+
+// attributeAliases maps the resource attributes commonly exposed by
+// compute resources to the inventory variable names Ansible playbooks
+// expect.
+var attributeAliases = map[string]string{
+	"public_ip":         "public_ip",
+	"access_ip_v4":      "public_ip",
+	"private_ip":        "private_ip",
+	"availability_zone": "availability_zone",
+}
+
+// computeResourceTypes are resource types known to represent a
+// compute instance across the platforms the installer supports.
+var computeResourceTypes = map[string]bool{
+	"aws_instance":                  true,
+	"libvirt_domain":                true,
+	"openstack_compute_instance_v2": true,
+	"azurerm_linux_virtual_machine": true,
+	"google_compute_instance":       true,
+}
+
+// isComputeResource reports whether a resource should be emitted as
+// an inventory host, rather than, say, an S3 bucket or a Route53
+// record: either it's a known compute type, or it exposes an address
+// Ansible could actually reach it at.
+func isComputeResource(resourceType string, attrs map[string]interface{}) bool {
+	if computeResourceTypes[resourceType] {
+		return true
+	}
+	for attr, alias := range attributeAliases {
+		if alias != "public_ip" && alias != "private_ip" {
+			continue
+		}
+		if _, ok := attrs[attr]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hostVars(id string, attrs map[string]interface{}) map[string]interface{} {
+	vars := map[string]interface{}{}
+	if id != "" {
+		vars["instance_id"] = id
+	}
+	for attr, alias := range attributeAliases {
+		if v, ok := attrs[attr]; ok {
+			vars[alias] = v
+		}
+	}
+	if host, ok := vars["public_ip"]; ok {
+		vars["ansible_host"] = host
+	} else if host, ok := vars["private_ip"]; ok {
+		vars["ansible_host"] = host
+	}
+	return vars
+}
+
+// ansibleGroup is a single group entry in an Ansible --list document.
+type ansibleGroup struct {
+	Hosts []string `json:"hosts"`
+}
+
+// List returns the document `ansible-inventory --list` expects: one
+// group per Terraform resource type, one group per module, and a
+// _meta.hostvars block so Ansible does not call back into --host for
+// every host.
+func (inv *Inventory) List() map[string]interface{} {
+	groups := map[string]*ansibleGroup{}
+	hostvars := map[string]interface{}{}
+
+	addTo := func(group, host string) {
+		g, ok := groups[group]
+		if !ok {
+			g = &ansibleGroup{}
+			groups[group] = g
+		}
+		g.Hosts = append(g.Hosts, host)
+	}
+
+	for _, host := range inv.Hosts {
+		addTo(host.Type, host.Name)
+		addTo(host.Group, host.Name)
+		hostvars[host.Name] = host.Vars
+	}
+
+	doc := map[string]interface{}{
+		"_meta": map[string]interface{}{"hostvars": hostvars},
+	}
+	for name, g := range groups {
+		doc[name] = g
+	}
+	return doc
+}
+
+// HostVars returns the per-host variables for name, as expected by
+// `ansible-inventory --host <name>`.
+func (inv *Inventory) HostVars(name string) map[string]interface{} {
+	for _, host := range inv.Hosts {
+		if host.Name == name {
+			return host.Vars
+		}
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []string{"flat.tfstate.json", "values.tfstate.json"}
+
+	for _, tc := range cases {
+		t.Run(tc, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tc))
+			require.NoError(t, err)
+
+			inv, err := Parse(data)
+			require.NoError(t, err)
+			require.Len(t, inv.Hosts, 2, "the aws_s3_bucket resource should not be emitted as a host")
+
+			list := inv.List()
+			assert.Contains(t, list, "aws_instance")
+			assert.Contains(t, list, "root")
+			assert.Contains(t, list, "masters")
+			assert.NotContains(t, list, "aws_s3_bucket")
+
+			bootstrapGroup, ok := list["root"].(*ansibleGroup)
+			require.True(t, ok)
+			assert.Contains(t, bootstrapGroup.Hosts, "aws_instance.bootstrap")
+
+			vars := inv.HostVars("aws_instance.bootstrap")
+			assert.Equal(t, "i-bootstrap", vars["instance_id"])
+			assert.Equal(t, "203.0.113.1", vars["public_ip"])
+			assert.Equal(t, "203.0.113.1", vars["ansible_host"])
+			assert.Equal(t, "us-east-1a", vars["availability_zone"])
+		})
+	}
+}
+
+func TestParseUnrecognized(t *testing.T) {
+	_, err := Parse([]byte(`{"foo": "bar"}`))
+	assert.Error(t, err)
+}
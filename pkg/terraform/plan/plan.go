@@ -0,0 +1,89 @@
+// Package plan parses the JSON produced by `terraform show -json
+// <planfile>` into a summary grouped by action, resource type, and
+// module, so callers can preview a pending Apply without walking the
+// full plan representation themselves.
+package plan
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceChange is a single planned change to a resource, as
+// surfaced by `terraform show -json`.
+type ResourceChange struct {
+	Address       string `json:"address"`
+	ModuleAddress string `json:"moduleAddress,omitempty"`
+	Type          string `json:"type"`
+	Name          string `json:"name"`
+}
+
+// Summary is a machine-readable overview of a Terraform plan.
+type Summary struct {
+	// RawPath is the path to the plan file the summary was generated
+	// from, for callers that want more than this summary exposes.
+	RawPath string `json:"rawPath"`
+
+	Adds     []ResourceChange `json:"adds"`
+	Changes  []ResourceChange `json:"changes"`
+	Destroys []ResourceChange `json:"destroys"`
+
+	ByType   map[string][]ResourceChange `json:"byType"`
+	ByModule map[string][]ResourceChange `json:"byModule"`
+}
+
+type rawPlan struct {
+	ResourceChanges []struct {
+		Address       string `json:"address"`
+		ModuleAddress string `json:"module_address"`
+		Type          string `json:"type"`
+		Name          string `json:"name"`
+		Change        struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// Parse unmarshals the JSON produced by `terraform show -json
+// <planfile>` into a Summary.
+func Parse(data []byte) (*Summary, error) {
+	var raw rawPlan
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Terraform plan JSON")
+	}
+
+	summary := &Summary{
+		ByType:   map[string][]ResourceChange{},
+		ByModule: map[string][]ResourceChange{},
+	}
+
+	for _, rc := range raw.ResourceChanges {
+		change := ResourceChange{
+			Address:       rc.Address,
+			ModuleAddress: rc.ModuleAddress,
+			Type:          rc.Type,
+			Name:          rc.Name,
+		}
+
+		module := rc.ModuleAddress
+		if module == "" {
+			module = "root"
+		}
+		summary.ByType[rc.Type] = append(summary.ByType[rc.Type], change)
+		summary.ByModule[module] = append(summary.ByModule[module], change)
+
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				summary.Adds = append(summary.Adds, change)
+			case "update":
+				summary.Changes = append(summary.Changes, change)
+			case "delete":
+				summary.Destroys = append(summary.Destroys, change)
+			}
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,59 @@
+package plan
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		platform string
+		adds     int
+		changes  int
+		destroys int
+		byType   map[string]int
+	}{
+		{
+			platform: "aws",
+			adds:     2,
+			changes:  1,
+			destroys: 0,
+			byType:   map[string]int{"aws_instance": 2, "aws_route53_record": 1, "aws_s3_bucket": 1},
+		},
+		{
+			platform: "libvirt",
+			adds:     2,
+			changes:  0,
+			destroys: 1,
+			byType:   map[string]int{"libvirt_domain": 1, "libvirt_network": 1},
+		},
+		{
+			platform: "openstack",
+			adds:     2,
+			changes:  0,
+			destroys: 1,
+			byType:   map[string]int{"openstack_compute_instance_v2": 2, "openstack_networking_floatingip_v2": 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.platform, func(t *testing.T) {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", tc.platform+"-plan.json"))
+			require.NoError(t, err)
+
+			summary, err := Parse(data)
+			require.NoError(t, err)
+
+			assert.Len(t, summary.Adds, tc.adds)
+			assert.Len(t, summary.Changes, tc.changes)
+			assert.Len(t, summary.Destroys, tc.destroys)
+			for typ, count := range tc.byType {
+				assert.Lenf(t, summary.ByType[typ], count, "resource type %q", typ)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/openshift/installer/pkg/lineprinter"
 	texec "github.com/openshift/installer/pkg/terraform/exec"
 	"github.com/openshift/installer/pkg/terraform/exec/plugins"
+	"github.com/openshift/installer/pkg/terraform/plan"
 )
 
 const (
@@ -23,6 +24,9 @@ const (
 
 	// VarFileName is the default name for Terraform var file.
 	VarFileName string = "terraform.tfvars"
+
+	// PlanFileName is the default name for Terraform plan files.
+	PlanFileName string = "terraform.tfplan"
 )
 
 // Apply unpacks the platform-specific Terraform modules into the
@@ -63,6 +67,57 @@ func Apply(dir string, platform string, extraArgs ...string) (path string, err e
 	return sf, nil
 }
 
+// Plan unpacks the platform-specific Terraform modules into the given
+// directory and then runs 'terraform plan' followed by 'terraform
+// show -json' to produce a machine-readable summary of the changes
+// Apply would make. It returns the absolute path of the plan file,
+// rooted in the specified directory, along with the parsed summary
+// and any errors from Terraform.
+func Plan(dir string, platform string, extraArgs ...string) (planPath string, changes *plan.Summary, err error) {
+	pwd, err := changeDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.Chdir(pwd)
+
+	err = unpackAndInit(platform)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pf := filepath.Join(dir, PlanFileName)
+	defaultArgs := []string{
+		"-input=false",
+		fmt.Sprintf("-state=%s", filepath.Join(dir, StateFileName)),
+		fmt.Sprintf("-out=%s", pf),
+	}
+	args := append(defaultArgs, extraArgs...)
+	args = append(args, dir)
+
+	lpDebug := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print}
+	lpError := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Error}).Print}
+	defer lpDebug.Close()
+	defer lpError.Close()
+
+	errBuf := &bytes.Buffer{}
+	if exitCode := texec.Plan(args, lpDebug, io.MultiWriter(errBuf, lpError)); exitCode != 0 {
+		return pf, nil, errors.Wrap(Diagnose(errBuf.String()), "failed to plan Terraform")
+	}
+
+	showBuf := &bytes.Buffer{}
+	if exitCode := texec.Show([]string{"-json", pf}, showBuf, lpError); exitCode != 0 {
+		return pf, nil, errors.New("failed to show Terraform plan")
+	}
+
+	summary, err := plan.Parse(showBuf.Bytes())
+	if err != nil {
+		return pf, nil, errors.Wrap(err, "failed to parse Terraform plan")
+	}
+	summary.RawPath = pf
+
+	return pf, summary, nil
+}
+
 // Destroy unpacks the platform-specific Terraform modules into the
 // given directory and then runs 'terraform init' and 'terraform
 // destroy'.
@@ -127,10 +182,20 @@ func unpackAndInit(platform string) (err error) {
 		return errors.Wrap(err, "failed to unpack Terraform modules")
 	}
 
-	if err := setupEmbeddedPlugins(); err != nil {
+	cacheDir, err := pluginCacheDir()
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve Terraform plugin cache directory")
+	}
+	pdir := filepath.Join(cacheDir, "openshift", "local")
+
+	if err := setupEmbeddedPlugins(pdir); err != nil {
 		return errors.Wrap(err, "failed to setup embedded Terraform plugins")
 	}
 
+	if err := addPluginCacheDirConfig(cacheDir); err != nil {
+		return errors.Wrap(err, "failed to configure Terraform plugin cache")
+	}
+
 	lpDebug := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Debug}).Print}
 	lpError := &lineprinter.LinePrinter{Print: (&lineprinter.Trimmer{WrappedPrint: logrus.Error}).Print}
 	defer lpDebug.Close()
@@ -142,7 +207,7 @@ func unpackAndInit(platform string) (err error) {
 	os.Setenv("TF_LOG", "trace")
 
 	args := []string{
-		fmt.Sprintf("-plugin-dir=%s", filepath.Join("plugins")),
+		fmt.Sprintf("-plugin-dir=%s", pdir),
 	}
 	args = append(args, ".")
 	if exitCode := texec.Init(args, lpDebug, lpError); exitCode != 0 {
@@ -151,15 +216,51 @@ func unpackAndInit(platform string) (err error) {
 	return nil
 }
 
-func setupEmbeddedPlugins() error {
+// pluginCacheDir resolves the shared directory that embedded
+// Terraform plugins are symlinked into once per installer version,
+// rather than being re-symlinked into every asset directory. It
+// honors INSTALLER_PLUGIN_CACHE_DIR, falling back to the
+// XDG-conventional cache location when unset.
+func pluginCacheDir() (string, error) {
+	if dir := os.Getenv("INSTALLER_PLUGIN_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to determine user cache directory")
+		}
+		cacheHome = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(cacheHome, "openshift-installer", "plugins"), nil
+}
+
+// addPluginCacheDirConfig appends a plugin_cache_dir stanza to the
+// terraform.rc unpacked into the current directory, mirroring the
+// opt-in local plugin cache Terraform supports upstream.
+func addPluginCacheDirConfig(cacheDir string) error {
+	f, err := os.OpenFile("terraform.rc", os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "\nplugin_cache_dir = %q\n", cacheDir)
+	return err
+}
+
+// setupEmbeddedPlugins symlinks (or copies) the embedded plugin
+// binaries into pdir, a directory under the shared plugin cache.
+func setupEmbeddedPlugins(pdir string) error {
 	execPath, err := os.Executable()
 	if err != nil {
 		return errors.Wrap(err, "failed to find path for the executable")
 	}
 
-	pdir := filepath.Join("plugins", "openshift", "local")
 	for name, plugin := range plugins.KnownPlugins {
-		dstDir := filepath.Join(pdir, plugin.Name, plugin.Version, fmt.Sprintf("linux_%s", runtime.GOARCH))
+		dstDir := pluginDestDir(pdir, plugin.Name, plugin.Version, runtime.GOOS, runtime.GOARCH)
 		if err := os.MkdirAll(dstDir, 0777); err != nil {
 			return err
 		}
@@ -173,13 +274,60 @@ func setupEmbeddedPlugins() error {
 			continue
 		}
 		logrus.Debugf("Symlinking plugin %s src: %q dst: %q", name, execPath, dst)
-		if err := os.Symlink(execPath, dst); err != nil {
+		if err := symlinkOrCopy(execPath, dst); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// pluginDestDir returns the directory Terraform's plugin discovery
+// expects a plugin binary to live in, keyed off the given GOOS/GOARCH
+// rather than the host the installer happens to be compiled for. This
+// matches the convention Terraform itself adopted for third-party
+// plugins once OS_ARCH knowledge was removed from core discovery.
+func pluginDestDir(pdir, name, version, goos, goarch string) string {
+	return filepath.Join(pdir, name, version, fmt.Sprintf("%s_%s", goos, goarch))
+}
+
+// symlink is os.Symlink, indirected so tests can force the symlink
+// path to fail without relying on real filesystem permissions.
+var symlink = os.Symlink
+
+// symlinkOrCopy symlinks dst to src, falling back to a hard copy of
+// src's contents when the platform does not support symlinks (e.g.
+// unprivileged Windows accounts without SeCreateSymbolicLinkPrivilege).
+// If dst already exists (e.g. another installer invocation sharing the
+// plugin cache dir created it concurrently), it is left as it is and
+// no error is returned.
+func symlinkOrCopy(src, dst string) error {
+	err := symlink(src, dst)
+	if err == nil || os.IsExist(err) {
+		return nil
+	}
+	if !os.IsPermission(err) && runtime.GOOS != "windows" {
+		return err
+	}
+
+	logrus.Debugf("Symlinking %q failed (%v); falling back to a copy", dst, err)
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrap(err, "failed to open plugin binary for copying")
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create plugin binary copy")
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return errors.Wrap(err, "failed to copy plugin binary")
+	}
+	return nil
+}
+
 func changeDir(dir string) (string, error) {
 	pwd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,89 @@
+package terraform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginCacheDir(t *testing.T) {
+	cases := []struct {
+		name            string
+		cacheDirEnv     string
+		xdgCacheHomeEnv string
+		expected        string
+	}{
+		{
+			name:        "INSTALLER_PLUGIN_CACHE_DIR set",
+			cacheDirEnv: "/var/cache/installer-plugins",
+			expected:    "/var/cache/installer-plugins",
+		},
+		{
+			name:            "falls back to XDG_CACHE_HOME",
+			xdgCacheHomeEnv: "/home/core/.cache",
+			expected:        filepath.Join("/home/core/.cache", "openshift-installer", "plugins"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("INSTALLER_PLUGIN_CACHE_DIR", tc.cacheDirEnv)
+			t.Setenv("XDG_CACHE_HOME", tc.xdgCacheHomeEnv)
+
+			dir, err := pluginCacheDir()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, dir)
+		})
+	}
+}
+
+func TestPluginDestDir(t *testing.T) {
+	cases := []struct {
+		goos   string
+		goarch string
+	}{
+		{goos: "linux", goarch: "amd64"},
+		{goos: "darwin", goarch: "amd64"},
+		{goos: "windows", goarch: "amd64"},
+		{goos: "linux", goarch: "arm64"},
+		{goos: "darwin", goarch: "arm64"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.goos+"_"+tc.goarch, func(t *testing.T) {
+			got := pluginDestDir(filepath.Join("plugins", "openshift", "local"), "terraform-provider-openshift", "1.0.0", tc.goos, tc.goarch)
+			expected := filepath.Join("plugins", "openshift", "local", "terraform-provider-openshift", "1.0.0", tc.goos+"_"+tc.goarch)
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestSymlinkOrCopyFallsBackToCopy(t *testing.T) {
+	orig := symlink
+	defer func() { symlink = orig }()
+	symlink = func(src, dst string) error {
+		return &os.LinkError{Op: "symlink", Old: src, New: dst, Err: os.ErrPermission}
+	}
+
+	dir, err := ioutil.TempDir("", "symlink-or-copy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	require.NoError(t, ioutil.WriteFile(src, []byte("plugin binary"), 0755))
+
+	dst := filepath.Join(dir, "dst")
+	require.NoError(t, symlinkOrCopy(src, dst))
+
+	info, err := os.Lstat(dst)
+	require.NoError(t, err)
+	assert.Zero(t, info.Mode()&os.ModeSymlink, "dst should be a real copy, not a symlink")
+
+	got, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "plugin binary", string(got))
+}